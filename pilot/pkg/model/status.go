@@ -0,0 +1,69 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TypedObject identifies a single Kubernetes object that status is being reported for.
+type TypedObject struct {
+	Name      string
+	Namespace string
+	// Kind is the GroupVersionKind of the object.
+	Kind schema.GroupVersionKind
+	// Generation is the object's metadata.generation at the time status was computed. Status
+	// writers stamp this onto every condition's ObservedGeneration so a consumer can tell
+	// whether a condition reflects the current spec or a stale one.
+	Generation int64
+}
+
+func (t TypedObject) String() string {
+	return fmt.Sprintf("%s/%s/%s", t.Kind.Kind, t.Namespace, t.Name)
+}
+
+// ConditionSet is an immutable snapshot of the conditions a reporter wants to report for an
+// object, keyed by condition Type.
+type ConditionSet struct {
+	conditions map[string]metav1.Condition
+}
+
+// NewConditionSet builds a ConditionSet from the given conditions. If two conditions share a
+// Type, the later one wins.
+func NewConditionSet(conditions ...metav1.Condition) ConditionSet {
+	m := make(map[string]metav1.Condition, len(conditions))
+	for _, c := range conditions {
+		m[c.Type] = c
+	}
+	return ConditionSet{conditions: m}
+}
+
+// Get returns the condition with the given Type, if present.
+func (c ConditionSet) Get(conditionType string) (metav1.Condition, bool) {
+	v, f := c.conditions[conditionType]
+	return v, f
+}
+
+// Conditions returns every condition in the set. Order is not significant.
+func (c ConditionSet) Conditions() []metav1.Condition {
+	out := make([]metav1.Condition, 0, len(c.conditions))
+	for _, v := range c.conditions {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionSet(t *testing.T) {
+	cs := NewConditionSet(
+		metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue},
+		metav1.Condition{Type: "Accepted", Status: metav1.ConditionFalse},
+	)
+
+	if got, f := cs.Get("Ready"); !f || got.Status != metav1.ConditionTrue {
+		t.Fatalf("expected to find Ready=True, got %v, found=%v", got, f)
+	}
+	if _, f := cs.Get("Missing"); f {
+		t.Fatalf("expected Missing to be absent")
+	}
+	if got := len(cs.Conditions()); got != 2 {
+		t.Fatalf("expected 2 conditions, got %d", got)
+	}
+}
+
+func TestConditionSetLaterWins(t *testing.T) {
+	cs := NewConditionSet(
+		metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Initializing"},
+		metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Healthy"},
+	)
+	got, f := cs.Get("Ready")
+	if !f || got.Reason != "Healthy" {
+		t.Fatalf("expected the later condition for a duplicate Type to win, got %v", got)
+	}
+}
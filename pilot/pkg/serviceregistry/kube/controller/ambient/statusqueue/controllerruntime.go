@@ -0,0 +1,138 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"istio.io/istio/pkg/kube/kclient"
+)
+
+// asRunnable adapts StatusQueue to manager.Runnable (and manager.LeaderElectionRunnable) so it
+// can be handed to mgr.Add(...) and participate in a controller-runtime manager's leader
+// election and graceful shutdown, instead of being driven by a raw stop channel.
+type asRunnable struct {
+	q *StatusQueue
+}
+
+// AsRunnable returns a manager.Runnable wrapping q. Call mgr.Add(statusqueue.AsRunnable(q)) in
+// place of a goroutine calling q.Run(stop).
+func AsRunnable(q *StatusQueue) manager.Runnable {
+	return asRunnable{q: q}
+}
+
+// Start implements manager.Runnable. It blocks until ctx is cancelled, mirroring how Run blocks
+// until its stop channel is closed.
+func (r asRunnable) Start(ctx context.Context) error {
+	stop := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+	r.q.Run(stop)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Status writes use a per-reporter
+// fieldManager and are safe from multiple writers, but running them on every replica would be
+// wasteful, so by default we only run on the elected leader.
+func (r asRunnable) NeedLeaderElection() bool {
+	return true
+}
+
+// RegisterFromInformer is a variant of Register for users driving reconciliation from a
+// controller-runtime cache.Cache/client.Object pair instead of a krt.Collection[T]. The
+// informer's event handlers keep a local map of writers, which backs the same statusReporter
+// that Register builds, so the rest of the status machinery (SSA patching,
+// field-manager-per-reporter, events, metrics) is unchanged.
+func RegisterFromInformer[T StatusWriter](
+	q *StatusQueue, name string, c ctrlcache.Cache, obj client.Object, toWriter func(client.Object) (string, T),
+	getPatcher func(T) (kclient.Patcher, []string), opts ...RegisterOption,
+) error {
+	informer, err := c.GetInformer(context.Background(), obj)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	store := make(map[string]T)
+
+	sr := statusReporter{
+		getObject: func(key string) (StatusWriter, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			w, f := store[key]
+			return w, f
+		},
+		patcher: func(writer StatusWriter) (kclient.Patcher, []string) {
+			return getPatcher(writer.(T))
+		},
+		start: func() {
+			informer.AddEventHandler(informerHandlers(q, name, &mu, store, toWriter))
+		},
+		authoritative: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(&sr)
+	}
+	q.reporters[name] = sr
+	return nil
+}
+
+// informerHandlers builds the client-go ResourceEventHandlerFuncs that keep store (guarded by mu)
+// in sync with the informer's view of the world and enqueue name/key for reconciliation on every
+// add, update, or delete. Split out from RegisterFromInformer so it can be exercised directly
+// without a real controller-runtime cache.Cache.
+func informerHandlers[T StatusWriter](
+	q *StatusQueue, name string, mu *sync.Mutex, store map[string]T, toWriter func(client.Object) (string, T),
+) cache.ResourceEventHandlerFuncs {
+	handle := func(o any) {
+		co, ok := o.(client.Object)
+		if !ok {
+			return
+		}
+		key, w := toWriter(co)
+		mu.Lock()
+		store[key] = w
+		mu.Unlock()
+		q.enqueue(statusItem{Key: key, Reporter: name})
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, newObj any) {
+			handle(newObj)
+		},
+		DeleteFunc: func(o any) {
+			if tombstone, ok := o.(cache.DeletedFinalStateUnknown); ok {
+				o = tombstone.Obj
+			}
+			co, ok := o.(client.Object)
+			if !ok {
+				return
+			}
+			key, _ := toWriter(co)
+			mu.Lock()
+			delete(store, key)
+			mu.Unlock()
+			q.enqueue(statusItem{Key: key, Reporter: name})
+		},
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// fakeClientObject is a minimal client.Object stand-in carrying only what toWriter needs.
+type fakeClientObject struct {
+	client.Object
+	name, namespace string
+}
+
+func (f *fakeClientObject) GetName() string      { return f.name }
+func (f *fakeClientObject) GetNamespace() string { return f.namespace }
+
+func fakeToWriter(o client.Object) (string, fakeWriter) {
+	key := o.GetNamespace() + "/" + o.GetName()
+	return key, fakeWriter{
+		target:     model.TypedObject{Name: o.GetName(), Namespace: o.GetNamespace()},
+		conditions: model.NewConditionSet(metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue}),
+	}
+}
+
+func TestInformerHandlersAddUpdateDelete(t *testing.T) {
+	q := NewQueue()
+	var mu sync.Mutex
+	store := make(map[string]fakeWriter)
+	handlers := informerHandlers(q, "test", &mu, store, fakeToWriter)
+
+	obj := &fakeClientObject{name: "foo", namespace: "ns"}
+
+	handlers.AddFunc(obj)
+	mu.Lock()
+	if _, f := store["ns/foo"]; !f {
+		mu.Unlock()
+		t.Fatalf("expected AddFunc to populate the store")
+	}
+	mu.Unlock()
+	if got := q.queue.Len(); got != 1 {
+		t.Fatalf("expected AddFunc to enqueue the key, got queue len %d", got)
+	}
+
+	handlers.UpdateFunc(obj, obj)
+	if got := q.queue.Len(); got != 1 {
+		t.Fatalf("expected UpdateFunc to coalesce with the still-pending item, got queue len %d", got)
+	}
+
+	handlers.DeleteFunc(obj)
+	mu.Lock()
+	if _, f := store["ns/foo"]; f {
+		mu.Unlock()
+		t.Fatalf("expected DeleteFunc to remove the key from the store")
+	}
+	mu.Unlock()
+	if got := q.queue.Len(); got != 1 {
+		t.Fatalf("expected DeleteFunc to still enqueue the key for reconciliation, got queue len %d", got)
+	}
+}
+
+func TestInformerHandlersDeleteHandlesTombstone(t *testing.T) {
+	q := NewQueue()
+	var mu sync.Mutex
+	store := map[string]fakeWriter{"ns/foo": {}}
+	handlers := informerHandlers(q, "test", &mu, store, fakeToWriter)
+
+	obj := &fakeClientObject{name: "foo", namespace: "ns"}
+	handlers.DeleteFunc(cache.DeletedFinalStateUnknown{Key: "ns/foo", Obj: obj})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, f := store["ns/foo"]; f {
+		t.Fatalf("expected a tombstone delete to still remove the key from the store")
+	}
+}
+
+func TestAsRunnableHonorsContextCancellation(t *testing.T) {
+	q := NewQueue()
+	r := AsRunnable(q)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Start(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected Start to return nil after cancellation, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected Start to return promptly after context cancellation")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// EventTypeFunc derives the corev1.Event type (corev1.EventTypeNormal or corev1.EventTypeWarning)
+// to use for a condition. See WithEventTypeFunc.
+type EventTypeFunc func(metav1.Condition) string
+
+// defaultEventTypeFor is the default EventTypeFunc: a condition that is True is a Normal event,
+// anything else (False, Unknown) is a Warning. This is the right default for Ready-style
+// conditions, but not every condition type is Ready-style -- reporters whose False state isn't
+// actually warning-worthy should override it with WithEventTypeFunc.
+func defaultEventTypeFor(c metav1.Condition) string {
+	if c.Status == metav1.ConditionTrue {
+		return corev1.EventTypeNormal
+	}
+	return corev1.EventTypeWarning
+}
+
+// emitTransitionEvents records a corev1.Event for every condition whose Status or Reason changed
+// between previous and desired, so `kubectl describe` and downstream tooling get a human-readable
+// history alongside the machine-readable condition. Conditions that are new (not present in
+// previous) are also reported, since their "transition" is from absent to present. eventType
+// derives the event type for a given condition; pass nil to use defaultEventTypeFor.
+func emitTransitionEvents(recorder record.EventRecorder, target model.TypedObject, previous, desired model.ConditionSet, eventType EventTypeFunc) {
+	if eventType == nil {
+		eventType = defaultEventTypeFor
+	}
+	for _, c := range desired.Conditions() {
+		prev, existed := previous.Get(c.Type)
+		if existed && prev.Status == c.Status && prev.Reason == c.Reason {
+			continue
+		}
+		recorder.Eventf(targetObjectReference(target), eventType(c), c.Reason, "%s", c.Message)
+	}
+}
+
+// targetObjectReference builds the corev1.ObjectReference the recorder needs from the
+// lightweight model.TypedObject we otherwise carry around.
+func targetObjectReference(target model.TypedObject) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: target.Kind.GroupVersion().String(),
+		Kind:       target.Kind.Kind,
+		Name:       target.Name,
+		Namespace:  target.Namespace,
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func TestEmitTransitionEventsUsesCustomEventType(t *testing.T) {
+	target := model.TypedObject{Name: "foo", Namespace: "default"}
+	previous := model.ConditionSet{}
+	desired := model.NewConditionSet(metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Pending"})
+
+	recorder := record.NewFakeRecorder(1)
+	// A custom EventTypeFunc that treats every condition as Normal, even False ones -- the
+	// opposite of defaultEventTypeFor -- to prove the override is actually consulted.
+	always := func(metav1.Condition) string { return corev1.EventTypeNormal }
+
+	emitTransitionEvents(recorder, target, previous, desired, always)
+
+	select {
+	case e := <-recorder.Events:
+		if !strings.HasPrefix(e, corev1.EventTypeNormal+" ") {
+			t.Fatalf("expected a Normal event from the custom EventTypeFunc, got %q", e)
+		}
+	default:
+		t.Fatalf("expected an event to be recorded, got none")
+	}
+}
+
+func TestDefaultEventTypeFor(t *testing.T) {
+	if got := defaultEventTypeFor(metav1.Condition{Status: metav1.ConditionTrue}); got != corev1.EventTypeNormal {
+		t.Fatalf("expected True to be Normal, got %s", got)
+	}
+	if got := defaultEventTypeFor(metav1.Condition{Status: metav1.ConditionFalse}); got != corev1.EventTypeWarning {
+		t.Fatalf("expected False to be Warning, got %s", got)
+	}
+}
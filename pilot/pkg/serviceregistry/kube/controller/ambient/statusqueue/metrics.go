@@ -0,0 +1,55 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"istio.io/istio/pkg/monitoring"
+)
+
+var (
+	reporterLabel = monitoring.MustCreateLabel("reporter")
+	resultLabel   = monitoring.MustCreateLabel("result")
+
+	statusQueueDepth = monitoring.NewGauge(
+		"status_queue_depth",
+		"Number of status patches currently queued for write.",
+	)
+
+	statusPatchesTotal = monitoring.NewSum(
+		"status_patches_total",
+		"Total number of status reconciles, by reporter and result. A reconcile that writes no patch counts as a success.",
+		monitoring.WithLabels(reporterLabel, resultLabel),
+	)
+
+	statusPatchLatency = monitoring.NewDistribution(
+		"status_patch_latency_seconds",
+		"Latency of a single status patch reconcile, by reporter.",
+		[]float64{.001, .005, .01, .05, .1, .5, 1, 5, 10},
+		monitoring.WithLabels(reporterLabel),
+	)
+
+	statusPatchConflictsTotal = monitoring.NewSum(
+		"status_patch_conflicts_total",
+		"Total number of status patches that failed due to a write conflict, by reporter.",
+		monitoring.WithLabels(reporterLabel),
+	)
+
+	statusAuthorityConflictsTotal = monitoring.NewSum(
+		"status_authority_conflicts_total",
+		"Total number of times a reporter observed an authoritative condition type it never wrote itself, "+
+			"indicating another controller is writing the same condition type.",
+		monitoring.WithLabels(reporterLabel),
+	)
+)
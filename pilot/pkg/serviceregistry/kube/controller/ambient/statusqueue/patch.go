@@ -0,0 +1,99 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+// conditionsPatch is the JSON shape of the status patch we send via server-side apply.
+// Conditions is +listType=map, keyed by `type` with merge patch strategy, so SSA merges
+// per-condition-type instead of replacing the whole slice. This is what allows multiple
+// reporters (each its own fieldManager) to each own a disjoint set of condition types on the
+// same object without clobbering one another's entries.
+type conditionsPatch struct {
+	Status conditionsPatchStatus `json:"status"`
+}
+
+type conditionsPatchStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+// translateToPatch builds the JSON status patch for target given the conditions a reporter
+// wants to write (desired), the conditions it wrote on the last reconcile (previous, empty if
+// this is the first reconcile for the key), and the condition types currently present on the
+// live object for this fieldManager (currentTypes). It returns nil when there is nothing worth
+// writing, so callers can skip the patch entirely.
+//
+// Every condition in desired is stamped with target.Generation as its ObservedGeneration before
+// being compared and emitted: reporters only describe what they observed, they never need to
+// know the generation of the object themselves. A condition that is otherwise identical to what
+// we wrote last time is still included in the patch (SSA is idempotent), but does not by itself
+// mark the patch as changed -- this avoids a write on every reconcile of an object whose
+// generation bumps more often than its status actually does.
+func translateToPatch(target model.TypedObject, desired, previous model.ConditionSet, currentTypes []string) []byte {
+	remaining := make(map[string]struct{}, len(currentTypes))
+	for _, t := range currentTypes {
+		remaining[t] = struct{}{}
+	}
+
+	changed := false
+	conditions := make([]metav1.Condition, 0, len(currentTypes))
+	for _, c := range desired.Conditions() {
+		c.ObservedGeneration = target.Generation
+		delete(remaining, c.Type)
+
+		if prev, f := previous.Get(c.Type); !f || !conditionUnchangedExceptGeneration(prev, c) {
+			changed = true
+		}
+		conditions = append(conditions, c)
+	}
+	// Any type we wrote previously but no longer want to report must be pruned. For a
+	// +listType=map merge patch, SSA prunes an owned entry by omitting it from the applied
+	// config -- submitting a tombstone condition would instead fail CRD validation, since
+	// Status/Reason/LastTransitionTime are +required on essentially every real Condition type.
+	if len(remaining) > 0 {
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	patch := conditionsPatch{Status: conditionsPatchStatus{Conditions: conditions}}
+	b, err := json.Marshal(patch)
+	if err != nil {
+		log.Errorf("failed to marshal status patch for %v: %v", target, err)
+		return nil
+	}
+	return b
+}
+
+// conditionUnchangedExceptGeneration reports whether a and b describe the same condition,
+// ignoring ObservedGeneration (which we stamp unconditionally on every reconcile).
+func conditionUnchangedExceptGeneration(a, b metav1.Condition) bool {
+	return a.Type == b.Type &&
+		a.Status == b.Status &&
+		a.Reason == b.Reason &&
+		a.Message == b.Message
+}
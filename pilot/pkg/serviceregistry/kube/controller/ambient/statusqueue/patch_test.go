@@ -0,0 +1,100 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+func TestTranslateToPatch(t *testing.T) {
+	target := model.TypedObject{Generation: 3}
+	ready := metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Healthy", Message: "all good"}
+
+	cases := []struct {
+		name         string
+		desired      model.ConditionSet
+		previous     model.ConditionSet
+		currentTypes []string
+		wantPatch    bool
+		wantPruned   bool
+	}{
+		{
+			name:         "first write",
+			desired:      model.NewConditionSet(ready),
+			previous:     model.ConditionSet{},
+			currentTypes: nil,
+			wantPatch:    true,
+		},
+		{
+			name:         "unchanged except generation is skipped",
+			desired:      model.NewConditionSet(ready),
+			previous:     model.NewConditionSet(ready),
+			currentTypes: []string{"Ready"},
+			wantPatch:    false,
+		},
+		{
+			name:         "status change is written",
+			desired:      model.NewConditionSet(metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Broken"}),
+			previous:     model.NewConditionSet(ready),
+			currentTypes: []string{"Ready"},
+			wantPatch:    true,
+		},
+		{
+			name:         "removed condition type is pruned, not tombstoned",
+			desired:      model.ConditionSet{},
+			previous:     model.NewConditionSet(ready),
+			currentTypes: []string{"Ready"},
+			wantPatch:    true,
+			wantPruned:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := translateToPatch(target, tc.desired, tc.previous, tc.currentTypes)
+			if tc.wantPatch && got == nil {
+				t.Fatalf("expected a patch to be written, got nil")
+			}
+			if !tc.wantPatch && got != nil {
+				t.Fatalf("expected no patch, got %s", got)
+			}
+			if tc.wantPruned {
+				// A pruned condition type must be omitted entirely, never submitted as a
+				// zero-valued tombstone (which would fail CRD validation).
+				if strings.Contains(string(got), `"type":"Ready"`) {
+					t.Fatalf("expected Ready to be pruned by omission, but patch still references it: %s", got)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionUnchangedExceptGeneration(t *testing.T) {
+	a := metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Healthy", Message: "ok", ObservedGeneration: 1}
+	b := a
+	b.ObservedGeneration = 2
+	if !conditionUnchangedExceptGeneration(a, b) {
+		t.Fatalf("expected conditions differing only by ObservedGeneration to be considered unchanged")
+	}
+	b.Reason = "Degraded"
+	if conditionUnchangedExceptGeneration(a, b) {
+		t.Fatalf("expected conditions differing by Reason to be considered changed")
+	}
+}
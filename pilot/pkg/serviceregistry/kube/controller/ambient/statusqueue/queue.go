@@ -15,7 +15,13 @@
 package statusqueue
 
 import (
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pkg/kube/controllers"
@@ -24,6 +30,10 @@ import (
 	istiolog "istio.io/istio/pkg/log"
 )
 
+// defaultMaxAttempts is how many attempts we make at a failing patch before giving up on it and
+// invoking the dead-letter callback, if any.
+const defaultMaxAttempts = 5
+
 var log = istiolog.RegisterScope("status", "status reporting")
 
 type StatusQueue struct {
@@ -32,6 +42,31 @@ type StatusQueue struct {
 	// reporters is a mapping of unique controller name -> status information.
 	// Note: this is user facing in the fieldManager!
 	reporters map[string]statusReporter
+
+	mu sync.Mutex
+	// previous records, per (Reporter, Key), the ConditionSet we last successfully reconciled.
+	// It lets us tell a condition that is truly unchanged from one that merely had its
+	// ObservedGeneration bumped, and forms the basis for detecting a type we never wrote
+	// suddenly showing up on the object (see statusReporter.authoritative).
+	previous map[reporterKey]model.ConditionSet
+	// attempts tracks consecutive reconcile failures per key, so we can invoke deadLetter once
+	// maxAttempts is exhausted instead of letting the workqueue retry (and drop) silently forever.
+	attempts map[reporterKey]int
+
+	// debounce coalesces enqueues of the same key within this window into a single reconcile.
+	// Zero disables coalescing and enqueues immediately, as before.
+	debounce time.Duration
+	// timers holds the pending debounce timer for a key, if one is outstanding.
+	timers map[reporterKey]*time.Timer
+
+	maxAttempts int
+	deadLetter  func(reporter, key string, err error)
+}
+
+// reporterKey identifies a single object as seen by a single reporter.
+type reporterKey struct {
+	Reporter string
+	Key      string
 }
 
 // statusItem represents the objects stored on the queue
@@ -40,17 +75,96 @@ type statusItem struct {
 	Reporter string
 }
 
+// QueueOption customizes the behavior of the queue as a whole (as opposed to RegisterOption,
+// which customizes a single reporter).
+type QueueOption func(*StatusQueue, *[]controllers.QueueOption)
+
+// WithRateLimiter overrides the default workqueue rate limiter used for retries. Use this to
+// combine a per-key exponential backoff with a global token bucket, so a spike of conflicting
+// writes (e.g. 409s from SSA) doesn't hot-loop the queue.
+func WithRateLimiter(rl workqueue.RateLimiter) QueueOption {
+	return func(_ *StatusQueue, opts *[]controllers.QueueOption) {
+		*opts = append(*opts, controllers.WithRateLimiter(rl))
+	}
+}
+
+// WithMaxAttempts overrides the default number of attempts (5) before a failing item is given up
+// on and passed to the dead-letter callback, if any.
+func WithMaxAttempts(n int) QueueOption {
+	return func(sq *StatusQueue, opts *[]controllers.QueueOption) {
+		sq.maxAttempts = n
+		*opts = append(*opts, controllers.WithMaxAttempts(n))
+	}
+}
+
+// WithDebounce coalesces repeated enqueues of the same (reporter, key) within window into a
+// single reconcile, rather than reconciling once per krt event. This matters in large ambient
+// meshes, where a single object can churn through many events in quick succession.
+func WithDebounce(window time.Duration) QueueOption {
+	return func(sq *StatusQueue, _ *[]controllers.QueueOption) {
+		sq.debounce = window
+	}
+}
+
+// WithDeadLetter registers fn to be called when a key exhausts maxAttempts, instead of the item
+// simply being dropped by the workqueue. Typical uses are flipping a global degraded gauge or
+// alerting, since the status for that object is now silently stale.
+func WithDeadLetter(fn func(reporter, key string, err error)) QueueOption {
+	return func(sq *StatusQueue, _ *[]controllers.QueueOption) {
+		sq.deadLetter = fn
+	}
+}
+
 // NewQueue builds a new status queue.
-func NewQueue() *StatusQueue {
+func NewQueue(opts ...QueueOption) *StatusQueue {
 	sq := &StatusQueue{
-		reporters: make(map[string]statusReporter),
+		reporters:   make(map[string]statusReporter),
+		previous:    make(map[reporterKey]model.ConditionSet),
+		attempts:    make(map[reporterKey]int),
+		timers:      make(map[reporterKey]*time.Timer),
+		maxAttempts: defaultMaxAttempts,
 	}
-	sq.queue = controllers.NewQueue("ambient status",
+	queueOpts := []controllers.QueueOption{
 		controllers.WithGenericReconciler(sq.reconcile),
-		controllers.WithMaxAttempts(5))
+		controllers.WithMaxAttempts(defaultMaxAttempts),
+	}
+	for _, opt := range opts {
+		opt(sq, &queueOpts)
+	}
+	sq.queue = controllers.NewQueue("ambient status", queueOpts...)
 	return sq
 }
 
+// enqueue adds key to the queue, coalescing it with any other enqueue of the same key within the
+// debounce window.
+func (q *StatusQueue) enqueue(item statusItem) {
+	if q.debounce == 0 {
+		q.addToQueue(item)
+		return
+	}
+	rk := reporterKey{Reporter: item.Reporter, Key: item.Key}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if t, f := q.timers[rk]; f {
+		t.Stop()
+	}
+	q.timers[rk] = time.AfterFunc(q.debounce, func() {
+		q.mu.Lock()
+		delete(q.timers, rk)
+		q.mu.Unlock()
+		q.addToQueue(item)
+	})
+}
+
+// addToQueue pushes item onto the underlying workqueue and updates the depth gauge.
+func (q *StatusQueue) addToQueue(item statusItem) {
+	q.queue.Add(item)
+	// Read depth from the workqueue itself, not a hand-rolled counter: the workqueue also
+	// re-adds items internally on a rate-limited requeue (e.g. after a 409 conflict), which
+	// bypasses this function, so anything we tracked ourselves would drift.
+	statusQueueDepth.Record(float64(q.queue.Len()))
+}
+
 // Run starts the queue, which will process items until the channel is closed
 func (q *StatusQueue) Run(stop <-chan struct{}) {
 	for _, r := range q.reporters {
@@ -59,9 +173,52 @@ func (q *StatusQueue) Run(stop <-chan struct{}) {
 	q.queue.Run(stop)
 }
 
-// reconcile processes a single queue item
+// reconcile is the entrypoint handed to the underlying workqueue. It wraps doReconcile with
+// attempt tracking, latency/result metrics, and dead-letter handling once maxAttempts is
+// exhausted, none of which the reconciliation logic itself needs to know about.
 func (q *StatusQueue) reconcile(raw any) error {
-	key := raw.(statusItem)
+	item := raw.(statusItem)
+	rk := reporterKey{Reporter: item.Reporter, Key: item.Key}
+
+	// The item has just been dequeued by the workqueue (this func is its reconciler), so the
+	// workqueue's own length already reflects the dequeue -- including retries it re-added
+	// internally, which never went through addToQueue.
+	statusQueueDepth.Record(float64(q.queue.Len()))
+
+	start := time.Now()
+	err := q.doReconcile(item)
+	statusPatchLatency.With(reporterLabel.Value(item.Reporter)).Record(time.Since(start).Seconds())
+
+	if err != nil {
+		statusPatchesTotal.With(reporterLabel.Value(item.Reporter), resultLabel.Value("error")).Increment()
+		if apierrors.IsConflict(err) {
+			statusPatchConflictsTotal.With(reporterLabel.Value(item.Reporter)).Increment()
+		}
+		q.mu.Lock()
+		q.attempts[rk]++
+		attempts := q.attempts[rk]
+		q.mu.Unlock()
+		if attempts >= q.maxAttempts {
+			q.mu.Lock()
+			delete(q.attempts, rk)
+			q.mu.Unlock()
+			if q.deadLetter != nil {
+				q.deadLetter(item.Reporter, item.Key, err)
+			}
+			log.WithLabels("key", item.Key).Errorf("giving up on status patch after %d attempts: %v", attempts, err)
+			return nil
+		}
+		return err
+	}
+	statusPatchesTotal.With(reporterLabel.Value(item.Reporter), resultLabel.Value("success")).Increment()
+	q.mu.Lock()
+	delete(q.attempts, rk)
+	q.mu.Unlock()
+	return nil
+}
+
+// doReconcile processes a single queue item
+func (q *StatusQueue) doReconcile(key statusItem) error {
 	log := log.WithLabels("key", key.Key)
 	log.Debugf("reconciling status")
 
@@ -76,6 +233,22 @@ func (q *StatusQueue) reconcile(raw any) error {
 	}
 	// Fetch the client to apply patches, and the set of current conditions
 	patcher, currentConditions := reporter.patcher(obj)
+
+	rk := reporterKey{Reporter: key.Reporter, Key: key.Key}
+	q.mu.Lock()
+	previous, seenBefore := q.previous[rk]
+	q.mu.Unlock()
+
+	desired := obj.GetConditions()
+	// On the first reconcile of a key we have seen since process start, previous is empty not
+	// because the conditions are actually new, but because our in-memory cache is. Treating that
+	// as "everything changed" would fire a bogus authority-conflict warning for every condition
+	// that simply survived a restart, and an event-storm of "new" events for every condition the
+	// reporter already owns. Only run these diff-based checks once we have a real baseline.
+	if seenBefore {
+		q.checkAuthority(reporter, key.Reporter, previous, desired, currentConditions)
+	}
+
 	// Turn the conditions into a patch. Using currentConditions, this will determine whether we can skip the patch entirely
 	// or if we need to send an empty patch. With an empty patch, SSA will automatically prune out anything *we* (identified by the fieldManager) wrote.
 	//
@@ -84,16 +257,59 @@ func (q *StatusQueue) reconcile(raw any) error {
 	// * Condition was there, but is now removed: No problem, we will at worst do a patch that wasn't needed.
 	// * Condition was not there, but now it was added: clearly some other controller is writing the same type as us, which is not really allowed.
 	targetObject := obj.GetStatusTarget()
-	status := translateToPatch(targetObject, obj.GetConditions(), currentConditions)
+	status := translateToPatch(targetObject, desired, previous, currentConditions)
+
+	if reporter.recorder != nil && seenBefore {
+		emitTransitionEvents(reporter.recorder, targetObject, previous, desired, reporter.eventType)
+	}
 
 	if status == nil {
 		log.Debugf("no status to write")
+		// Nothing needed writing, so desired is already reflected on the object: safe to adopt
+		// as our new baseline.
+		q.mu.Lock()
+		q.previous[rk] = desired
+		q.mu.Unlock()
 		return nil
 	}
 	log.Debugf("writing patch %v", string(status))
 	// Pass key.Reporter as the fieldManager. This ensures we have a unique value there.
 	// This means we could have multiple unique writers for the same object, as long as they have a unique set of conditions.
-	return patcher.ApplyStatus(targetObject.Name, targetObject.Namespace, types.ApplyPatchType, status, key.Reporter)
+	if err := patcher.ApplyStatus(targetObject.Name, targetObject.Namespace, types.ApplyPatchType, status, key.Reporter); err != nil {
+		// Do NOT update q.previous here: the write never landed, so the next reconcile must
+		// still see the old baseline and retry the same patch, rather than diffing against
+		// desired and concluding (wrongly) that nothing changed.
+		return err
+	}
+	q.mu.Lock()
+	q.previous[rk] = desired
+	q.mu.Unlock()
+	return nil
+}
+
+// checkAuthority warns when a reporter declared a condition type authoritative (via
+// RegisterWithSubresource) but that type shows up live on the object without us having written
+// it ourselves: the only way that happens is another controller writing the same type we claim
+// to own.
+func (q *StatusQueue) checkAuthority(reporter statusReporter, name string, previous, desired model.ConditionSet, currentTypes []string) {
+	if len(reporter.authoritative) == 0 {
+		return
+	}
+	wrote := map[string]struct{}{}
+	for _, c := range previous.Conditions() {
+		wrote[c.Type] = struct{}{}
+	}
+	for _, t := range currentTypes {
+		if !reporter.authoritative[t] {
+			continue
+		}
+		if _, f := wrote[t]; f {
+			continue
+		}
+		statusAuthorityConflictsTotal.With(reporterLabel.Value(name)).Increment()
+		log.Warnf("reporter %q claims authority over condition %q, but it is present without us ever having written it; "+
+			"another controller is likely writing the same condition type", name, t)
+	}
 }
 
 // StatusWriter is a type that can write status messages
@@ -109,12 +325,60 @@ type statusReporter struct {
 	getObject func(string) (StatusWriter, bool)
 	patcher   func(StatusWriter) (kclient.Patcher, []string)
 	start     func()
+	// authoritative is the set of condition types this reporter claims exclusive ownership of.
+	// See WithAuthoritativeConditions.
+	authoritative map[string]bool
+	// recorder, if set, is used to emit a corev1.Event whenever a condition transitions.
+	// See WithEventRecorder.
+	recorder record.EventRecorder
+	// eventType derives the event type for a transitioned condition. Defaults to
+	// defaultEventTypeFor if unset. See WithEventTypeFunc.
+	eventType EventTypeFunc
+}
+
+// RegisterOption customizes the behavior of Register for a single reporter.
+type RegisterOption func(*statusReporter)
+
+// WithAuthoritativeConditions declares that this reporter is the sole expected writer of the
+// given condition types. If one of these types is ever observed on the live object without the
+// reporter having written it itself, the queue assumes some other controller is writing the same
+// condition type and logs a warning (and increments a metric) rather than silently overwriting it.
+// The check compares against the last reconciled conditions, so it does not run on the first
+// reconcile of a key since process start.
+func WithAuthoritativeConditions(types ...string) RegisterOption {
+	return func(sr *statusReporter) {
+		for _, t := range types {
+			sr.authoritative[t] = true
+		}
+	}
+}
+
+// WithEventRecorder wires recorder into the reporter so that a corev1.Event is emitted on the
+// target object whenever one of the reporter's conditions transitions Status or Reason.
+// Transitions are computed against the last reconciled conditions, so the first reconcile of a
+// key since process start emits no events.
+func WithEventRecorder(recorder record.EventRecorder) RegisterOption {
+	return func(sr *statusReporter) {
+		sr.recorder = recorder
+	}
+}
+
+// WithEventTypeFunc overrides how the event type (Normal vs Warning) is derived for a
+// transitioned condition. The default treats True as Normal and everything else as Warning, which
+// is right for Ready-style conditions but not necessarily for others; reporters whose conditions
+// don't follow that convention should supply their own.
+func WithEventTypeFunc(fn EventTypeFunc) RegisterOption {
+	return func(sr *statusReporter) {
+		sr.eventType = fn
+	}
 }
 
 // Register registers a collection to have status reconciled.
 // The Collection is expected to produce objects that implement StatusWriter, which tells us what status to write.
 // The name is user facing, and ends up as a fieldManager for server-side-apply. It must be unique.
-func Register[T StatusWriter](q *StatusQueue, name string, col krt.Collection[T], getPatcher func(T) (kclient.Patcher, []string)) {
+func Register[T StatusWriter](
+	q *StatusQueue, name string, col krt.Collection[T], getPatcher func(T) (kclient.Patcher, []string), opts ...RegisterOption,
+) {
 	sr := statusReporter{
 		getObject: func(s string) (StatusWriter, bool) {
 			if o := col.GetKey(krt.Key[T](s)); o != nil {
@@ -131,12 +395,16 @@ func Register[T StatusWriter](q *StatusQueue, name string, col krt.Collection[T]
 				ol := o.Latest()
 				key := string(krt.GetKey(ol))
 				log.Debugf("registering key for processing: %s", key)
-				q.queue.Add(statusItem{
+				q.enqueue(statusItem{
 					Key:      key,
 					Reporter: name,
 				})
 			})
 		},
+		authoritative: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(&sr)
 	}
 	q.reporters[name] = sr
-}
\ No newline at end of file
+}
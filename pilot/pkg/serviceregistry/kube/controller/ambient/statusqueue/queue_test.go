@@ -0,0 +1,106 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statusqueue
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/kube/kclient"
+)
+
+type fakeWriter struct {
+	target     model.TypedObject
+	conditions model.ConditionSet
+}
+
+func (f fakeWriter) GetStatusTarget() model.TypedObject { return f.target }
+func (f fakeWriter) GetConditions() model.ConditionSet  { return f.conditions }
+
+type fakePatcher struct {
+	err error
+}
+
+func (f fakePatcher) ApplyStatus(name, namespace string, pt types.PatchType, data []byte, fieldManager string) error {
+	return f.err
+}
+
+func TestDebounceCoalescesRapidEnqueues(t *testing.T) {
+	q := NewQueue(WithDebounce(20 * time.Millisecond))
+	item := statusItem{Key: "ns/name", Reporter: "test"}
+
+	for i := 0; i < 5; i++ {
+		q.enqueue(item)
+	}
+	if got := q.queue.Len(); got != 0 {
+		t.Fatalf("expected debounced enqueues to not hit the queue yet, got len %d", got)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := q.queue.Len(); got != 1 {
+		t.Fatalf("expected exactly one coalesced item on the queue, got %d", got)
+	}
+}
+
+func TestDeadLetterInvokedAfterMaxAttempts(t *testing.T) {
+	var mu sync.Mutex
+	deadLetterCalls := 0
+	q := NewQueue(WithMaxAttempts(2), WithDeadLetter(func(reporter, key string, err error) {
+		mu.Lock()
+		deadLetterCalls++
+		mu.Unlock()
+	}))
+
+	writer := fakeWriter{
+		target:     model.TypedObject{Name: "foo", Namespace: "ns"},
+		conditions: model.NewConditionSet(metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue}),
+	}
+	q.reporters["test"] = statusReporter{
+		getObject: func(string) (StatusWriter, bool) { return writer, true },
+		patcher: func(StatusWriter) (kclient.Patcher, []string) {
+			return fakePatcher{err: errors.New("boom")}, nil
+		},
+		authoritative: map[string]bool{},
+	}
+
+	item := statusItem{Key: "ns/foo", Reporter: "test"}
+
+	// Below maxAttempts: the error should propagate so the workqueue's own rate limiter retries it.
+	if err := q.reconcile(item); err == nil {
+		t.Fatalf("expected a failing attempt below maxAttempts to return an error")
+	}
+	mu.Lock()
+	if deadLetterCalls != 0 {
+		t.Fatalf("dead letter should not fire before maxAttempts is reached")
+	}
+	mu.Unlock()
+
+	// maxAttempts (2) is now exhausted: reconcile should swallow the error so the item isn't
+	// retried forever, and the dead-letter callback should fire exactly once.
+	if err := q.reconcile(item); err != nil {
+		t.Fatalf("expected reconcile to swallow the error once dead-lettered, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if deadLetterCalls != 1 {
+		t.Fatalf("expected dead letter to fire exactly once, got %d", deadLetterCalls)
+	}
+}